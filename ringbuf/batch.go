@@ -0,0 +1,126 @@
+package ringbuf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ReadBatch drains up to len(recs) committed records into recs, reusing
+// each Record's existing RawSample buffer where possible, and returns
+// the number of records filled.
+//
+// Unlike calling ReadInto len(recs) times, ReadBatch holds the Reader's
+// lock for the whole call and only consults the poller once the ring
+// has been drained to errEOR, which amortizes the lock and syscall
+// overhead across the batch. maxWait bounds how long ReadBatch will wait
+// for the first record; a zero maxWait uses the deadline set by
+// SetDeadline instead. Once at least one record has been read, ReadBatch
+// returns early with a nil error as soon as the ring runs dry, rather
+// than waiting to fill recs.
+//
+// If SetBlocking(false) was called, ReadBatch never consults the
+// poller: it fills recs with whatever is already committed and returns
+// os.ErrDeadlineExceeded instead of blocking if the ring is dry before a
+// single record was read, mirroring ReadInto.
+func (r *Reader) ReadBatch(recs []Record, maxWait time.Duration) (int, error) {
+	if len(recs) == 0 {
+		return 0, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ring == nil {
+		return 0, fmt.Errorf("ringbuffer: %w", ErrClosed)
+	}
+
+	if !r.blocking {
+		n := 0
+		for n < len(recs) {
+			ok, err := r.tryReadLocked(&recs[n])
+			if err != nil {
+				return n, err
+			}
+			if !ok {
+				break
+			}
+			n++
+		}
+		if n == 0 {
+			return 0, os.ErrDeadlineExceeded
+		}
+		return n, nil
+	}
+
+	deadline := r.deadline
+	if maxWait > 0 {
+		deadline = time.Now().Add(maxWait)
+	}
+
+	n := 0
+	for n < len(recs) {
+		if !r.haveData {
+			_, err := r.poller.Wait(r.epollEvents[:cap(r.epollEvents)], deadline)
+			if errors.Is(err, os.ErrDeadlineExceeded) && !r.ring.isEmpty() {
+				err = nil
+			}
+			if err != nil {
+				if n > 0 && errors.Is(err, os.ErrDeadlineExceeded) {
+					return n, nil
+				}
+				return n, err
+			}
+			r.haveData = true
+		}
+
+		err := readRecord(r.ring, &recs[n])
+		if err == errBusy {
+			continue
+		}
+		if err == errDiscard {
+			if err := r.handleDiscard(); err != nil {
+				return n, err
+			}
+			continue
+		}
+		if err == errEOR {
+			r.haveData = false
+			if n > 0 {
+				return n, nil
+			}
+			// A spurious wakeup: the poller reported readiness but the
+			// ring is already empty again. Loop back to Wait instead of
+			// returning an empty batch, matching ReadInto's behaviour.
+			continue
+		}
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	return n, nil
+}
+
+// GetBuffer returns a buffer from the Reader's internal pool for use as
+// a Record's RawSample, or nil if the pool is currently empty, in which
+// case readRecord allocates one as needed. Pair with PutBuffer to
+// recycle RawSample slices across ReadInto or ReadBatch calls without
+// allocating on the hot path.
+func (r *Reader) GetBuffer() []byte {
+	if buf, ok := r.bufferPool.Get().([]byte); ok {
+		return buf[:0]
+	}
+	return nil
+}
+
+// PutBuffer returns a buffer previously obtained from GetBuffer, or a
+// Record.RawSample no longer in use, to the Reader's internal pool.
+func (r *Reader) PutBuffer(buf []byte) {
+	if buf == nil {
+		return
+	}
+	r.bufferPool.Put(buf) //nolint:staticcheck // slice header is put by value, backing array is what's reused
+}
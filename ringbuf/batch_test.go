@@ -0,0 +1,42 @@
+package ringbuf
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestReadBatchLoopsOnSpuriousEOR guards against ReadBatch returning an
+// empty, zero-error batch on a spurious wakeup (haveData true but the
+// ring actually empty), which previously turned a caller that retries
+// immediately into a busy loop instead of waiting out maxWait.
+func TestReadBatchLoopsOnSpuriousEOR(t *testing.T) {
+	m := mustNewRingBufMap(t)
+
+	r, err := NewReader(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	// Simulate the spurious wakeup directly: haveData claims a sample is
+	// ready, but the ring is in fact empty, so the first readRecord call
+	// hits errEOR with n still at 0.
+	r.haveData = true
+
+	recs := make([]Record, 4)
+	start := time.Now()
+	n, err := r.ReadBatch(recs, 50*time.Millisecond)
+	elapsed := time.Since(start)
+
+	if n != 0 {
+		t.Fatalf("n = %d, want 0", n)
+	}
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("err = %v, want os.ErrDeadlineExceeded", err)
+	}
+	if elapsed < 25*time.Millisecond {
+		t.Fatalf("ReadBatch returned after %v instead of waiting out maxWait (busy-loop bug)", elapsed)
+	}
+}
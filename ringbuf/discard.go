@@ -0,0 +1,52 @@
+package ringbuf
+
+// discardKind selects how a Reader reacts to a record the producing BPF
+// program discarded with bpf_ringbuf_discard().
+type discardKind int
+
+const (
+	// discardIgnore preserves the historical Reader behaviour of
+	// silently skipping discarded records. It is the zero value so that
+	// Readers default to it without an explicit SetDiscardPolicy call.
+	discardIgnore discardKind = iota
+	discardCount
+	discardCallback
+	discardStrict
+)
+
+// DiscardPolicy controls how a Reader reacts to a record the producing
+// BPF program discarded by calling bpf_ringbuf_discard() instead of
+// bpf_ringbuf_submit(). This is a routine, intentional part of a BPF
+// program's logic (e.g. it reserved space speculatively and decided the
+// event wasn't worth emitting) and is unrelated to the consumer falling
+// behind: bpf_ringbuf has no drop-oldest/overwrite behaviour, since
+// bpf_ringbuf_reserve() simply fails in-kernel, and nothing is ever
+// written, when the ring is full.
+//
+// Construct one with Count, Callback or Strict and pass it to
+// Reader.SetDiscardPolicy.
+type DiscardPolicy struct {
+	kind     discardKind
+	callback func(n uint64)
+}
+
+// Count tallies discarded records, retrievable via Reader.DiscardedSamples.
+func Count() DiscardPolicy {
+	return DiscardPolicy{kind: discardCount}
+}
+
+// Callback invokes fn with the number of newly discarded records (always
+// 1) whenever the Reader skips one, in addition to accumulating the
+// total for Reader.DiscardedSamples.
+func Callback(fn func(n uint64)) DiscardPolicy {
+	return DiscardPolicy{kind: discardCallback, callback: fn}
+}
+
+// Strict causes Read, ReadInto and TryRead to return an error as soon as
+// a discarded record is encountered, instead of skipping it. This turns
+// a BPF program's routine use of bpf_ringbuf_discard() into a read
+// error, so it's only appropriate when the caller knows its program
+// never discards.
+func Strict() DiscardPolicy {
+	return DiscardPolicy{kind: discardStrict}
+}
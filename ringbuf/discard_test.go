@@ -0,0 +1,51 @@
+package ringbuf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDiscardPolicyConstructors(t *testing.T) {
+	var zero DiscardPolicy
+	if zero.kind != discardIgnore {
+		t.Fatalf("zero value kind = %v, want %v", zero.kind, discardIgnore)
+	}
+
+	if got := Count(); got.kind != discardCount {
+		t.Fatalf("Count() kind = %v, want %v", got.kind, discardCount)
+	}
+
+	if got := Strict(); got.kind != discardStrict {
+		t.Fatalf("Strict() kind = %v, want %v", got.kind, discardStrict)
+	}
+
+	var lost uint64
+	cb := Callback(func(n uint64) { lost += n })
+	if cb.kind != discardCallback || cb.callback == nil {
+		t.Fatalf("Callback() = %+v, want kind %v with a non-nil callback", cb, discardCallback)
+	}
+
+	cb.callback(3)
+	if lost != 3 {
+		t.Fatalf("callback invoked with total %d, want 3", lost)
+	}
+}
+
+// TestHandleDiscardStrictIsErrDiscarded checks that callers can detect a
+// Strict discard with errors.Is(err, ErrDiscarded) rather than matching
+// on the error string, mirroring how ErrClosed is detected.
+func TestHandleDiscardStrictIsErrDiscarded(t *testing.T) {
+	m := mustNewRingBufMap(t)
+
+	r, err := NewReader(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	r.SetDiscardPolicy(Strict())
+
+	if err := r.handleDiscard(); !errors.Is(err, ErrDiscarded) {
+		t.Fatalf("handleDiscard() = %v, want errors.Is(err, ErrDiscarded)", err)
+	}
+}
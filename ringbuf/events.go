@@ -0,0 +1,114 @@
+package ringbuf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// eventsPollInterval bounds how long a single ReadInto call inside
+// Events/EventsInto blocks, so that ctx cancellation is noticed promptly
+// without requiring the poller itself to be interruptible by a context.
+const eventsPollInterval = 100 * time.Millisecond
+
+// errEventsRequireBlocking is sent on the error channel when Events or
+// EventsInto is used on a Reader that has SetBlocking(false): the two
+// features are incompatible, since ReadInto then returns immediately
+// instead of waiting out eventsPollInterval, turning the draining
+// goroutine into an unthrottled busy loop.
+var errEventsRequireBlocking = errors.New("ringbuf: Events/EventsInto require a blocking Reader")
+
+// Events spawns a goroutine that drains r and delivers records on the
+// returned channel. Both the record and the error channel are closed
+// once ctx is cancelled or r is closed; at most one error is ever sent.
+//
+// This mirrors the callback-oriented usage pattern most libbpf-based
+// tools want, while keeping it idiomatic Go: callers range over the
+// record channel and select on the error channel instead of writing
+// their own "for { r.Read(); select { ... } }" loop.
+//
+// Events repeatedly calls r.SetDeadline to poll at eventsPollInterval,
+// overriding any deadline set directly on r, and requires r to be
+// blocking (the default): it returns errEventsRequireBlocking on the
+// error channel if SetBlocking(false) is in effect.
+func (r *Reader) Events(ctx context.Context, bufSize int) (<-chan Record, <-chan error) {
+	records := make(chan Record, bufSize)
+	errs := make(chan error, 1)
+
+	go r.runEvents(ctx, records, errs, true, nil)
+
+	return records, errs
+}
+
+// EventsInto is like Events, but delivers records on a channel supplied
+// by the caller. If pool is non-nil, each Record's RawSample is drawn
+// from pool via Get before reading, so callers that Put the buffer back
+// once they're done with a Record can build a zero-allocation pipeline.
+//
+// Unlike Events, EventsInto does not close ch, since it doesn't own it;
+// only the returned error channel is closed. Like Events, it takes over
+// r's deadline and requires r to be blocking.
+func (r *Reader) EventsInto(ctx context.Context, ch chan<- Record, pool *sync.Pool) <-chan error {
+	errs := make(chan error, 1)
+
+	go r.runEvents(ctx, ch, errs, false, pool)
+
+	return errs
+}
+
+func (r *Reader) runEvents(ctx context.Context, ch chan<- Record, errs chan error, ownsCh bool, pool *sync.Pool) {
+	defer close(errs)
+	if ownsCh {
+		defer close(ch)
+	}
+
+	for {
+		r.mu.Lock()
+		blocking := r.blocking
+		r.mu.Unlock()
+		if !blocking {
+			select {
+			case errs <- fmt.Errorf("%w: call SetBlocking(true) before Events/EventsInto", errEventsRequireBlocking):
+			case <-ctx.Done():
+			}
+			return
+		}
+
+		var buf []byte
+		if pool != nil {
+			buf, _ = pool.Get().([]byte)
+		}
+		rec := Record{RawSample: buf}
+
+		r.SetDeadline(time.Now().Add(eventsPollInterval))
+		err := r.ReadInto(&rec)
+		if err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				if pool != nil && buf != nil {
+					pool.Put(buf[:0]) //nolint:staticcheck
+				}
+				select {
+				case <-ctx.Done():
+					return
+				default:
+					continue
+				}
+			}
+
+			select {
+			case errs <- err:
+			case <-ctx.Done():
+			}
+			return
+		}
+
+		select {
+		case ch <- rec:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package ringbuf
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestEventsContextCancel checks that cancelling ctx closes both
+// channels returned by Events.
+func TestEventsContextCancel(t *testing.T) {
+	m := mustNewRingBufMap(t)
+
+	r, err := NewReader(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	records, errs := r.Events(ctx, 1)
+	cancel()
+
+	select {
+	case _, ok := <-records:
+		if ok {
+			t.Fatal("expected records channel to be closed without a value")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("records channel was not closed after ctx was cancelled")
+	}
+
+	select {
+	case _, ok := <-errs:
+		if ok {
+			t.Fatal("expected errs channel to be closed without a value")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("errs channel was not closed after ctx was cancelled")
+	}
+}
+
+// TestEventsRequiresBlocking checks that Events refuses to run on a
+// Reader configured with SetBlocking(false), instead of spinning in an
+// unthrottled busy loop.
+func TestEventsRequiresBlocking(t *testing.T) {
+	m := mustNewRingBufMap(t)
+
+	r, err := NewReader(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	r.SetBlocking(false)
+
+	_, errs := r.Events(context.Background(), 1)
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, errEventsRequireBlocking) {
+			t.Fatalf("err = %v, want errEventsRequireBlocking", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Events to report errEventsRequireBlocking")
+	}
+}
@@ -0,0 +1,283 @@
+package ringbuf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/internal/epoll"
+	"github.com/cilium/ebpf/internal/unix"
+)
+
+// ring tracks the state needed to read from a single RingBuf map that was
+// registered with a MultiReader.
+type ring struct {
+	ring *ringbufEventRing
+	tag  any
+}
+
+// MultiReader allows reading from many RingBuf maps using a single
+// epoll-backed poller, mirroring libbpf's ring_buffer__add model.
+//
+// Add and Remove only ever take mu for short, non-blocking work (the
+// epoll_ctl call and mapping or unmapping the ring), never while a Wait
+// is in flight, so both are safe to
+// call while another goroutine is blocked in ReadInto or PollCallbacks:
+// neither call blocks on the other. A map added while a read is blocked
+// starts contributing records to that same read as soon as the kernel
+// reports it readable, since epoll_wait observes fds added to its epoll
+// instance after it was called. Records read from a MultiReader carry
+// the tag their map was registered under in Record.Ring, so callers can
+// demultiplex many BPF programs' output on a single goroutine without a
+// Reader and a goroutine per map.
+type MultiReader struct {
+	poller *epoll.Poller
+
+	// mu protects read/write access to the MultiReader structure.
+	mu       sync.Mutex
+	rings    map[int]*ring // keyed by map FD, which doubles as epoll data
+	pending  []*ring       // rings with data left over from the last Wait
+	events   []unix.EpollEvent
+	deadline time.Time
+	closed   bool
+}
+
+// NewMultiReader creates a MultiReader with no rings registered.
+//
+// Use Add to register RingBuf maps before calling ReadInto or
+// PollCallbacks.
+func NewMultiReader() (*MultiReader, error) {
+	poller, err := epoll.New()
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultiReader{
+		poller: poller,
+		rings:  make(map[int]*ring),
+	}, nil
+}
+
+// Add registers ringbufMap with the MultiReader. tag is returned via
+// Record.Ring for every record subsequently read from ringbufMap, and is
+// invoked as a callback by PollCallbacks if it has type func(Record).
+func (mr *MultiReader) Add(ringbufMap *ebpf.Map, tag any) error {
+	if ringbufMap.Type() != ebpf.RingBuf {
+		return fmt.Errorf("invalid Map type: %s", ringbufMap.Type())
+	}
+
+	maxEntries := int(ringbufMap.MaxEntries())
+	if maxEntries == 0 || (maxEntries&(maxEntries-1)) != 0 {
+		return fmt.Errorf("ringbuffer map size %d is zero or not a power of two", maxEntries)
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if mr.closed {
+		return fmt.Errorf("multireader: %w", ErrClosed)
+	}
+
+	fd := ringbufMap.FD()
+	if _, ok := mr.rings[fd]; ok {
+		return fmt.Errorf("map fd %d already added", fd)
+	}
+
+	eventRing, err := newRingBufEventRing(fd, maxEntries)
+	if err != nil {
+		return fmt.Errorf("failed to create ringbuf ring: %w", err)
+	}
+
+	if err := mr.poller.Add(fd, fd); err != nil {
+		eventRing.Close()
+		return fmt.Errorf("add ringbuf map to poller: %w", err)
+	}
+
+	mr.rings[fd] = &ring{ring: eventRing, tag: tag}
+	if len(mr.events) < len(mr.rings) {
+		mr.events = make([]unix.EpollEvent, len(mr.rings))
+	}
+
+	return nil
+}
+
+// Remove unregisters ringbufMap, which must have previously been passed
+// to Add. Any records still buffered for ringbufMap are discarded.
+func (mr *MultiReader) Remove(ringbufMap *ebpf.Map) error {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	fd := ringbufMap.FD()
+	r, ok := mr.rings[fd]
+	if !ok {
+		return fmt.Errorf("map fd %d was not added", fd)
+	}
+
+	if err := mr.poller.Delete(fd); err != nil {
+		return fmt.Errorf("remove ringbuf map from poller: %w", err)
+	}
+
+	r.ring.Close()
+	delete(mr.rings, fd)
+
+	for i, p := range mr.pending {
+		if p == r {
+			mr.pending = append(mr.pending[:i], mr.pending[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// Close frees resources used by the MultiReader.
+//
+// It interrupts calls to ReadInto and PollCallbacks.
+func (mr *MultiReader) Close() error {
+	if err := mr.poller.Close(); err != nil {
+		if errors.Is(err, os.ErrClosed) {
+			return nil
+		}
+		return err
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	for _, r := range mr.rings {
+		r.ring.Close()
+	}
+	mr.rings = nil
+	mr.pending = nil
+	mr.closed = true
+
+	return nil
+}
+
+// SetDeadline controls how long ReadInto and PollCallbacks will block
+// waiting for samples.
+//
+// Passing a zero time.Time will remove the deadline.
+func (mr *MultiReader) SetDeadline(t time.Time) {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	mr.deadline = t
+}
+
+// Read the next record produced by any registered ring.
+func (mr *MultiReader) Read() (Record, error) {
+	var rec Record
+	return rec, mr.ReadInto(&rec)
+}
+
+// ReadInto is like Read except that it allows reusing Record and
+// associated buffers. The ring that produced rec is reported in
+// rec.Ring.
+func (mr *MultiReader) ReadInto(rec *Record) error {
+	return mr.readInto(rec)
+}
+
+// readInto implements ReadInto/PollCallbacks. It deliberately drops mu
+// before calling the blocking mr.poller.Wait, and re-acquires it
+// afterwards: epoll_ctl (used by Add/Remove) is safe to call on the same
+// epoll instance as an in-flight epoll_wait, so releasing mu here is
+// what actually lets Add/Remove run without blocking on a concurrent
+// read, rather than just not deadlocking on mu.
+func (mr *MultiReader) readInto(rec *Record) error {
+	for {
+		mr.mu.Lock()
+
+		if mr.closed {
+			mr.mu.Unlock()
+			return fmt.Errorf("multireader: %w", ErrClosed)
+		}
+
+		for len(mr.pending) > 0 {
+			r := mr.pending[0]
+
+			err := readRecord(r.ring, rec)
+			if err == errBusy || err == errDiscard {
+				continue
+			}
+			if err == errEOR {
+				mr.pending = mr.pending[1:]
+				continue
+			}
+			if err != nil {
+				mr.mu.Unlock()
+				return err
+			}
+
+			rec.Ring = r.tag
+			mr.mu.Unlock()
+			return nil
+		}
+
+		if len(mr.rings) == 0 {
+			mr.mu.Unlock()
+			return fmt.Errorf("multireader: no rings added")
+		}
+
+		events, deadline := mr.events, mr.deadline
+		mr.mu.Unlock()
+
+		n, err := mr.poller.Wait(events[:cap(events)], deadline)
+		if err != nil {
+			return err
+		}
+
+		mr.mu.Lock()
+		for _, event := range events[:n] {
+			if r, ok := mr.rings[int(event.Fd)]; ok {
+				mr.pending = append(mr.pending, r)
+			}
+		}
+		mr.mu.Unlock()
+	}
+}
+
+// PollCallbacks waits for records across all registered rings until
+// deadline elapses, dispatching each record to the tag registered for
+// its ring via Add.
+//
+// It returns nil when deadline elapses without error, mirroring
+// ring_buffer__poll's behaviour of treating a timeout as "nothing to
+// do" rather than a failure. It returns an error as soon as it reads a
+// record whose ring was registered with a tag that isn't a
+// func(Record): since Add accepts any tag, mixing PollCallbacks with
+// rings registered for ReadInto's plain Record.Ring demuxing is a
+// programming error, not something PollCallbacks can recover from by
+// dropping the record.
+func (mr *MultiReader) PollCallbacks(deadline time.Time) error {
+	mr.mu.Lock()
+	prev := mr.deadline
+	mr.deadline = deadline
+	mr.mu.Unlock()
+
+	defer func() {
+		mr.mu.Lock()
+		mr.deadline = prev
+		mr.mu.Unlock()
+	}()
+
+	var rec Record
+	for {
+		err := mr.readInto(&rec)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		cb, ok := rec.Ring.(func(Record))
+		if !ok {
+			return fmt.Errorf("multireader: tag %#v registered for ring is not a func(Record)", rec.Ring)
+		}
+		cb(rec)
+	}
+}
@@ -0,0 +1,72 @@
+package ringbuf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cilium/ebpf"
+)
+
+// mustNewRingBufMap creates a minimal RingBuf map for use in tests, or
+// skips the test if ring buffer maps aren't supported by the running
+// kernel.
+func mustNewRingBufMap(tb testing.TB) *ebpf.Map {
+	tb.Helper()
+
+	m, err := ebpf.NewMap(&ebpf.MapSpec{
+		Type:       ebpf.RingBuf,
+		MaxEntries: 4096,
+	})
+	if err != nil {
+		tb.Skipf("ring buffer maps not supported: %v", err)
+	}
+	tb.Cleanup(func() { m.Close() })
+
+	return m
+}
+
+// TestMultiReaderAddDuringBlockedRead guards against Add/Remove
+// blocking behind a concurrent ReadInto that's parked in poller.Wait
+// with no deadline, which previously defeated the whole point of
+// MultiReader: letting callers register rings while another goroutine
+// is already reading.
+func TestMultiReaderAddDuringBlockedRead(t *testing.T) {
+	m1 := mustNewRingBufMap(t)
+
+	mr, err := NewMultiReader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+
+	if err := mr.Add(m1, "m1"); err != nil {
+		t.Fatal(err)
+	}
+
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		var rec Record
+		mr.ReadInto(&rec) //nolint:errcheck // expected to error out when Close is called below
+	}()
+
+	// Give the goroutine above a chance to enter poller.Wait before we
+	// race Add against it.
+	time.Sleep(50 * time.Millisecond)
+
+	m2 := mustNewRingBufMap(t)
+	addDone := make(chan error, 1)
+	go func() { addDone <- mr.Add(m2, "m2") }()
+
+	select {
+	case err := <-addDone:
+		if err != nil {
+			t.Fatalf("Add returned an error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Add blocked behind a concurrent blocked ReadInto")
+	}
+
+	mr.Close()
+	<-readDone
+}
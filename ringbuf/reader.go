@@ -15,9 +15,15 @@ import (
 )
 
 var (
-	ErrClosed  = os.ErrClosed
+	ErrClosed = os.ErrClosed
+
+	// ErrDiscarded is returned by ReadInto, TryRead and ReadBatch when
+	// SetDiscardPolicy(Strict()) is in effect and the next record was
+	// discarded by the producing BPF program.
+	ErrDiscarded = errors.New("sample discarded")
+
 	errEOR     = errors.New("end of ring")
-	errDiscard = errors.New("sample discarded")
+	errDiscard = ErrDiscarded
 	errBusy    = errors.New("sample not committed yet")
 )
 
@@ -44,6 +50,11 @@ type Record struct {
 
 	// The minimum number of bytes remaining in the ring buffer after this Record has been read.
 	Remaining int
+
+	// Ring identifies which ring produced this Record. It is set to the
+	// tag passed to MultiReader.Add and is nil for Records read from a
+	// plain Reader.
+	Ring any
 }
 
 // Read a record from an event ring.
@@ -92,6 +103,7 @@ func readRecord(rd *ringbufEventRing, rec *Record) error {
 	rd.storeConsumer()
 	rec.RawSample = rec.RawSample[:header.dataLen()]
 	rec.Remaining = rd.remaining()
+	rec.Ring = nil
 	return nil
 }
 
@@ -101,12 +113,16 @@ type Reader struct {
 	poller *epoll.Poller
 
 	// mu protects read/write access to the Reader structure
-	mu          sync.Mutex
-	ring        *ringbufEventRing
-	epollEvents []unix.EpollEvent
-	haveData    bool
-	deadline    time.Time
-	bufferSize  int
+	mu               sync.Mutex
+	ring             *ringbufEventRing
+	epollEvents      []unix.EpollEvent
+	haveData         bool
+	deadline         time.Time
+	bufferSize       int
+	blocking         bool
+	discardPolicy    DiscardPolicy
+	discardedSamples uint64
+	bufferPool       sync.Pool
 }
 
 // NewReader creates a new BPF ringbuf reader.
@@ -141,6 +157,7 @@ func NewReader(ringbufMap *ebpf.Map) (*Reader, error) {
 		ring:        ring,
 		epollEvents: make([]unix.EpollEvent, 1),
 		bufferSize:  ring.size(),
+		blocking:    true,
 	}, nil
 }
 
@@ -177,6 +194,44 @@ func (r *Reader) SetDeadline(t time.Time) {
 	r.deadline = t
 }
 
+// SetBlocking controls whether Read and ReadInto block waiting for
+// samples.
+//
+// By default a Reader is blocking. Passing false makes ReadInto behave
+// like TryRead, returning os.ErrDeadlineExceeded immediately instead of
+// waiting when the ring is empty.
+func (r *Reader) SetBlocking(blocking bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.blocking = blocking
+}
+
+// SetDiscardPolicy controls what happens when the reader encounters a
+// record the producing BPF program discarded with
+// bpf_ringbuf_discard(). This is unrelated to the consumer falling
+// behind: bpf_ringbuf has no overwrite/drop-oldest behaviour, so this
+// only ever reacts to discards the producer made deliberately.
+//
+// The default policy silently skips discarded records, matching the
+// historical behaviour of Read and ReadInto.
+func (r *Reader) SetDiscardPolicy(policy DiscardPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.discardPolicy = policy
+}
+
+// DiscardedSamples returns the number of records skipped because the
+// producing BPF program discarded them, as tracked by the Count and
+// Callback discard policies.
+func (r *Reader) DiscardedSamples() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.discardedSamples
+}
+
 // Read the next record from the BPF ringbuf.
 //
 // Returns os.ErrClosed if Close is called on the Reader, or os.ErrDeadlineExceeded
@@ -188,6 +243,10 @@ func (r *Reader) Read() (Record, error) {
 }
 
 // ReadInto is like Read except that it allows reusing Record and associated buffers.
+//
+// If SetBlocking(false) was called, ReadInto behaves like TryRead and
+// returns os.ErrDeadlineExceeded instead of blocking when the ring is
+// empty.
 func (r *Reader) ReadInto(rec *Record) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -196,6 +255,17 @@ func (r *Reader) ReadInto(rec *Record) error {
 		return fmt.Errorf("ringbuffer: %w", ErrClosed)
 	}
 
+	if !r.blocking {
+		ok, err := r.tryReadLocked(rec)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return os.ErrDeadlineExceeded
+		}
+		return nil
+	}
+
 	for {
 		if !r.haveData {
 			_, err := r.poller.Wait(r.epollEvents[:cap(r.epollEvents)], r.deadline)
@@ -214,7 +284,13 @@ func (r *Reader) ReadInto(rec *Record) error {
 			err := readRecord(r.ring, rec)
 			// Not using errors.Is which is quite a bit slower
 			// For a tight loop it might make a difference
-			if err == errBusy || err == errDiscard {
+			if err == errBusy {
+				continue
+			}
+			if err == errDiscard {
+				if err := r.handleDiscard(); err != nil {
+					return err
+				}
 				continue
 			}
 			if err == errEOR {
@@ -226,6 +302,61 @@ func (r *Reader) ReadInto(rec *Record) error {
 	}
 }
 
+// TryRead reads a record without blocking. It reports ok == false if no
+// record was currently available in the ring, in which case err is nil.
+func (r *Reader) TryRead(rec *Record) (ok bool, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ring == nil {
+		return false, fmt.Errorf("ringbuffer: %w", ErrClosed)
+	}
+
+	return r.tryReadLocked(rec)
+}
+
+// tryReadLocked reads a single record from the ring without invoking the
+// poller. r.mu must be held.
+func (r *Reader) tryReadLocked(rec *Record) (bool, error) {
+	for {
+		err := readRecord(r.ring, rec)
+		if err == errBusy {
+			continue
+		}
+		if err == errDiscard {
+			if err := r.handleDiscard(); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if err == errEOR {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+}
+
+// handleDiscard applies the configured DiscardPolicy to a record the
+// producing BPF program discarded. r.mu must be held.
+func (r *Reader) handleDiscard() error {
+	switch r.discardPolicy.kind {
+	case discardCount:
+		r.discardedSamples++
+	case discardCallback:
+		r.discardedSamples++
+		if r.discardPolicy.callback != nil {
+			r.discardPolicy.callback(1)
+		}
+	case discardStrict:
+		return errDiscard
+	}
+
+	return nil
+}
+
 // BufferSize returns the size in bytes of the ring buffer
 func (r *Reader) BufferSize() int {
 	return r.bufferSize